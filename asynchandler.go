@@ -0,0 +1,127 @@
+package spoor
+
+import (
+	"context"
+	"sync"
+)
+
+// OverflowPolicy controls what an AsyncHandler does when its buffer is full.
+type OverflowPolicy int
+
+const (
+	// Block makes Emit wait until the buffer has room.
+	Block OverflowPolicy = iota
+	// DropNewest discards the record that doesn't fit.
+	DropNewest
+	// DropOldest discards the oldest buffered record to make room.
+	DropOldest
+)
+
+// AsyncHandler wraps another ILogHandler and dispatches records to it from a
+// dedicated goroutine, so a slow sink (file, network, service) doesn't block
+// the caller of Logger.Log while the buffer has room. Once the buffer is
+// full, the OverflowPolicy decides: Block waits, the Drop policies don't.
+type AsyncHandler struct {
+	target   ILogHandler
+	ch       chan *LogRecord
+	overflow OverflowPolicy
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	closed   bool
+}
+
+func NewAsyncHandler(target ILogHandler, bufferSize int, overflow OverflowPolicy) *AsyncHandler {
+	asyncHandler := new(AsyncHandler)
+	asyncHandler.target = target
+	asyncHandler.ch = make(chan *LogRecord, bufferSize)
+	asyncHandler.overflow = overflow
+	asyncHandler.wg.Add(1)
+	go asyncHandler.run()
+	return asyncHandler
+}
+
+func (h *AsyncHandler) run() {
+	defer h.wg.Done()
+	for logRecord := range h.ch {
+		h.target.Handle(logRecord)
+	}
+}
+
+func (h *AsyncHandler) GetLevel() LogLevel {
+	return h.target.GetLevel()
+}
+
+func (h *AsyncHandler) SetLevel(level LogLevel) {
+	h.target.SetLevel(level)
+}
+
+func (h *AsyncHandler) GetFormatter() Formatter {
+	return h.target.GetFormatter()
+}
+
+func (h *AsyncHandler) SetFormatter(formatter Formatter) {
+	h.target.SetFormatter(formatter)
+}
+
+func (h *AsyncHandler) IncludeCaller() bool {
+	return h.target.IncludeCaller()
+}
+
+func (h *AsyncHandler) SetIncludeCaller(includeCaller bool) {
+	h.target.SetIncludeCaller(includeCaller)
+}
+
+func (h *AsyncHandler) Format(logRecord *LogRecord) string {
+	return h.target.Format(logRecord)
+}
+
+func (h *AsyncHandler) Handle(logRecord *LogRecord) {
+	h.Emit(logRecord)
+}
+
+func (h *AsyncHandler) Emit(logRecord *LogRecord) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	select {
+	case h.ch <- logRecord:
+		return
+	default:
+	}
+	switch h.overflow {
+	case DropNewest:
+	case DropOldest:
+		select {
+		case <-h.ch:
+		default:
+		}
+		select {
+		case h.ch <- logRecord:
+		default:
+		}
+	default: // Block
+		h.ch <- logRecord
+	}
+}
+
+// Close stops accepting new records and waits for the buffered ones to drain
+// through the target handler, returning ctx's error if it expires first.
+func (h *AsyncHandler) Close(ctx context.Context) error {
+	h.mu.Lock()
+	h.closed = true
+	close(h.ch)
+	h.mu.Unlock()
+	done := make(chan struct{})
+	go func() {
+		h.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,182 @@
+// Package config bootstraps spoor loggers, handlers and formatters from a
+// declarative JSON or YAML document, similar to Python's
+// logging.config.fileConfig/dictConfig.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/henkburgstra/spoor"
+)
+
+// HandlerFactory builds a handler from the params given for its entry in
+// the config document's "handlers" section (including "class", "level"
+// and "formatter", which callers may ignore).
+type HandlerFactory func(params map[string]interface{}) (spoor.ILogHandler, error)
+
+var handlerFactories = struct {
+	sync.RWMutex
+	items map[string]HandlerFactory
+}{items: make(map[string]HandlerFactory)}
+
+// RegisterHandlerFactory makes a handler class available to LoadFile and
+// LoadReader under name. Packages that define their own ILogHandler (e.g.
+// filehandler, servicehandler) call this from an init() so they can be
+// instantiated from config without config importing them back.
+func RegisterHandlerFactory(name string, factory HandlerFactory) {
+	handlerFactories.Lock()
+	defer handlerFactories.Unlock()
+	handlerFactories.items[name] = factory
+}
+
+func init() {
+	RegisterHandlerFactory("stream", func(params map[string]interface{}) (spoor.ILogHandler, error) {
+		return spoor.NewStreamHandler(), nil
+	})
+}
+
+type document struct {
+	Formatters map[string]map[string]interface{} `json:"formatters" yaml:"formatters"`
+	Handlers   map[string]map[string]interface{} `json:"handlers" yaml:"handlers"`
+	Loggers    map[string]loggerSection          `json:"loggers" yaml:"loggers"`
+}
+
+type loggerSection struct {
+	Level     string   `json:"level" yaml:"level"`
+	Handlers  []string `json:"handlers" yaml:"handlers"`
+	Propagate *bool    `json:"propagate" yaml:"propagate"`
+}
+
+// LoadFile reads and applies the config document at path, inferring its
+// format ("json" or "yaml") from the file extension.
+func LoadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return LoadReader(file, formatFromExt(path))
+}
+
+func formatFromExt(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return "yaml"
+	default:
+		return "json"
+	}
+}
+
+// LoadReader reads and applies a config document of the given format
+// ("json" or "yaml") from r.
+func LoadReader(r io.Reader, format string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	var doc document
+	switch strings.ToLower(format) {
+	case "json":
+		err = json.Unmarshal(data, &doc)
+	case "yaml", "yml":
+		err = yaml.Unmarshal(data, &doc)
+	default:
+		return fmt.Errorf("config: unsupported format %q", format)
+	}
+	if err != nil {
+		return fmt.Errorf("config: parsing %s document: %w", format, err)
+	}
+	return apply(&doc)
+}
+
+func apply(doc *document) error {
+	formatters := make(map[string]spoor.Formatter, len(doc.Formatters))
+	for name, params := range doc.Formatters {
+		formatters[name] = buildFormatter(params)
+	}
+
+	handlers := make(map[string]spoor.ILogHandler, len(doc.Handlers))
+	for name, params := range doc.Handlers {
+		handler, err := buildHandler(params)
+		if err != nil {
+			return fmt.Errorf("config: building handler %q: %w", name, err)
+		}
+		if formatterName, ok := params["formatter"].(string); ok {
+			if formatter, ok := formatters[formatterName]; ok {
+				handler.SetFormatter(formatter)
+			}
+		}
+		if level, ok := params["level"].(string); ok {
+			handler.SetLevel(parseLevel(level))
+		}
+		handlers[name] = handler
+	}
+
+	for name, section := range doc.Loggers {
+		logger := spoor.GetLogger(name)
+		if section.Level != "" {
+			logger.SetLevel(parseLevel(section.Level))
+		}
+		if section.Propagate != nil {
+			logger.SetPropagate(*section.Propagate)
+		}
+		for _, handlerName := range section.Handlers {
+			handler, ok := handlers[handlerName]
+			if !ok {
+				return fmt.Errorf("config: logger %q references unknown handler %q", name, handlerName)
+			}
+			logger.AddHandler(handler)
+		}
+	}
+	return nil
+}
+
+func buildHandler(params map[string]interface{}) (spoor.ILogHandler, error) {
+	class, _ := params["class"].(string)
+	handlerFactories.RLock()
+	factory, ok := handlerFactories.items[class]
+	handlerFactories.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no handler factory registered for class %q", class)
+	}
+	return factory(params)
+}
+
+func buildFormatter(params map[string]interface{}) spoor.Formatter {
+	format, _ := params["format"].(string)
+	datefmt, _ := params["datefmt"].(string)
+	if class, _ := params["class"].(string); strings.ToLower(class) == "json" {
+		if datefmt != "" {
+			return spoor.NewJSONFormatter(datefmt)
+		}
+		return spoor.NewJSONFormatter()
+	}
+	return spoor.NewFormatter(format, datefmt)
+}
+
+func parseLevel(name string) spoor.LogLevel {
+	switch strings.ToUpper(name) {
+	case "DEBUG":
+		return spoor.DEBUG
+	case "INFO":
+		return spoor.INFO
+	case "WARNING", "WARN":
+		return spoor.WARNING
+	case "ERROR":
+		return spoor.ERROR
+	case "CRITICAL":
+		return spoor.CRITICAL
+	case "FATAL":
+		return spoor.FATAL
+	default:
+		return spoor.INFO
+	}
+}
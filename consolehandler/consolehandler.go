@@ -0,0 +1,133 @@
+// Package consolehandler provides a StreamHandler that colorizes the level
+// token of each line it writes, based on the record's level.
+package consolehandler
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/henkburgstra/spoor"
+)
+
+// ColorScheme maps each level to the ANSI escape sequence used to colorize
+// its token. An empty string leaves that level uncolored.
+type ColorScheme struct {
+	Debug    string
+	Info     string
+	Warning  string
+	Error    string
+	Critical string
+	Fatal    string
+}
+
+// DefaultColorScheme is cyan DEBUG, blue INFO, yellow WARNING, red ERROR
+// and bold red CRITICAL/FATAL.
+var DefaultColorScheme = ColorScheme{
+	Debug:    "\x1b[36m",
+	Info:     "\x1b[34m",
+	Warning:  "\x1b[33m",
+	Error:    "\x1b[31m",
+	Critical: "\x1b[1;31m",
+	Fatal:    "\x1b[1;31m",
+}
+
+const resetCode = "\x1b[0m"
+
+// ConsoleHandler wraps a StreamHandler and colorizes the "{levelname}"
+// token of each formatted line, auto-detecting whether the target writer is
+// a TTY. Colors are disabled when the target is piped, when NO_COLOR is
+// set, or after ForceColor(false); they're always on after ForceColor(true).
+type ConsoleHandler struct {
+	spoor.StreamHandler
+	writer     io.Writer
+	scheme     ColorScheme
+	forceColor bool
+	forceSet   bool
+}
+
+func NewConsoleHandler(stream ...io.Writer) *ConsoleHandler {
+	var out io.Writer = os.Stdout
+	if len(stream) > 0 {
+		out = stream[0]
+	}
+	consoleHandler := new(ConsoleHandler)
+	consoleHandler.StreamHandler = *spoor.NewStreamHandler(out)
+	consoleHandler.writer = out
+	consoleHandler.scheme = DefaultColorScheme
+	return consoleHandler
+}
+
+// SetColorScheme remaps the colors used per level.
+func (h *ConsoleHandler) SetColorScheme(scheme ColorScheme) {
+	h.scheme = scheme
+}
+
+// ForceColor overrides the TTY/NO_COLOR auto-detection: true always
+// colorizes, false never does.
+func (h *ConsoleHandler) ForceColor(force bool) {
+	h.forceColor = force
+	h.forceSet = true
+}
+
+func (h *ConsoleHandler) colorEnabled() bool {
+	if h.forceSet {
+		return h.forceColor
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	file, ok := h.writer.(*os.File)
+	if !ok {
+		return false
+	}
+	return term.IsTerminal(int(file.Fd()))
+}
+
+func (h *ConsoleHandler) colorFor(level spoor.LogLevel) string {
+	switch level {
+	case spoor.DEBUG:
+		return h.scheme.Debug
+	case spoor.INFO:
+		return h.scheme.Info
+	case spoor.WARNING:
+		return h.scheme.Warning
+	case spoor.ERROR:
+		return h.scheme.Error
+	case spoor.CRITICAL:
+		return h.scheme.Critical
+	case spoor.FATAL:
+		return h.scheme.Fatal
+	default:
+		return ""
+	}
+}
+
+func (h *ConsoleHandler) Handle(logRecord *spoor.LogRecord) {
+	h.Emit(logRecord)
+}
+
+func (h *ConsoleHandler) Emit(logRecord *spoor.LogRecord) {
+	line := h.Format(logRecord)
+	if h.colorEnabled() {
+		line = colorizeLevel(line, logRecord.GetLevel().String(), h.colorFor(logRecord.GetLevel()))
+	}
+	fmt.Fprintln(h.writer, line)
+}
+
+// colorizeLevel wraps the first occurrence of levelName in line with color,
+// leaving the rest of the line untouched so downstream scrapers see clean
+// text after stripping ANSI codes.
+func colorizeLevel(line, levelName, color string) string {
+	if color == "" {
+		return line
+	}
+	idx := strings.Index(line, levelName)
+	if idx < 0 {
+		return line
+	}
+	return line[:idx] + color + levelName + resetCode + line[idx+len(levelName):]
+}
@@ -1,23 +1,63 @@
 package filehandler
 
 import (
-	"github.com/henkburgstra/spoor"
 	"os"
+	"sync"
+
+	"github.com/henkburgstra/spoor"
 )
 
+// FileHandler is a StreamHandler that writes to a named file, opened in
+// append mode. Emit is safe for concurrent use.
 type FileHandler struct {
 	spoor.StreamHandler
 	filename string
 	mode     string
+	file     *os.File
+	mu       sync.Mutex
 }
 
 func NewFileHandler(filename string, mode string) *FileHandler {
 	fileHandler := new(FileHandler)
+	fileHandler.StreamHandler = *spoor.NewStreamHandler()
 	fileHandler.filename = filename
 	fileHandler.mode = mode
+	fileHandler.open()
+	return fileHandler
+}
 
-	logfile, _ := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, os.FileMode(0666))
-	fileHandler.StreamHandler = *spoor.NewStreamHandler(logfile)
+// open (re)opens h.filename and points the embedded StreamHandler at it,
+// preserving whatever formatter, level and includeCaller the caller has
+// configured. It must not rebuild the StreamHandler, since doRollover and
+// Reopen both call it on an already-configured handler.
+func (h *FileHandler) open() error {
+	logfile, err := os.OpenFile(h.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, os.FileMode(0666))
+	if err != nil {
+		return err
+	}
+	h.file = logfile
+	h.SetWriter(logfile)
+	return nil
+}
 
-	return fileHandler
+// Reopen closes and reopens the underlying file. It lets external log
+// rotation (e.g. logrotate triggering on SIGHUP) hand the handler a fresh
+// file descriptor for the (possibly renamed) filename.
+func (h *FileHandler) Reopen() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.file != nil {
+		h.file.Close()
+	}
+	return h.open()
+}
+
+func (h *FileHandler) Handle(logRecord *spoor.LogRecord) {
+	h.Emit(logRecord)
+}
+
+func (h *FileHandler) Emit(logRecord *spoor.LogRecord) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.StreamHandler.Emit(logRecord)
 }
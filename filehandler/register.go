@@ -0,0 +1,51 @@
+package filehandler
+
+import (
+	"fmt"
+
+	"github.com/henkburgstra/spoor"
+	"github.com/henkburgstra/spoor/config"
+)
+
+func init() {
+	config.RegisterHandlerFactory("file", newFileHandlerFromConfig)
+	config.RegisterHandlerFactory("rotating_file", newRotatingFileHandlerFromConfig)
+}
+
+func newFileHandlerFromConfig(params map[string]interface{}) (spoor.ILogHandler, error) {
+	filename, _ := params["filename"].(string)
+	if filename == "" {
+		return nil, fmt.Errorf("filehandler: config requires a filename")
+	}
+	mode, _ := params["mode"].(string)
+	return NewFileHandler(filename, mode), nil
+}
+
+func newRotatingFileHandlerFromConfig(params map[string]interface{}) (spoor.ILogHandler, error) {
+	filename, _ := params["filename"].(string)
+	if filename == "" {
+		return nil, fmt.Errorf("filehandler: config requires a filename")
+	}
+	mode, _ := params["mode"].(string)
+	maxBytes, _ := toInt64(params["maxBytes"])
+	backupCount, _ := toInt(params["backupCount"])
+	return NewRotatingFileHandler(filename, mode, maxBytes, backupCount), nil
+}
+
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	case float64:
+		return int64(n), true
+	default:
+		return 0, false
+	}
+}
+
+func toInt(v interface{}) (int, bool) {
+	n, ok := toInt64(v)
+	return int(n), ok
+}
@@ -0,0 +1,70 @@
+package filehandler
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/henkburgstra/spoor"
+)
+
+// RotatingFileHandler is a FileHandler that rolls the file over to
+// name.1, name.2, ... before a write would take it past MaxBytes, keeping at most
+// BackupCount backups, matching Python's logging.handlers.RotatingFileHandler.
+type RotatingFileHandler struct {
+	FileHandler
+	maxBytes    int64
+	backupCount int
+	size        int64
+}
+
+func NewRotatingFileHandler(filename string, mode string, maxBytes int64, backupCount int) *RotatingFileHandler {
+	rotatingFileHandler := new(RotatingFileHandler)
+	rotatingFileHandler.FileHandler = *NewFileHandler(filename, mode)
+	rotatingFileHandler.maxBytes = maxBytes
+	rotatingFileHandler.backupCount = backupCount
+	if info, err := os.Stat(filename); err == nil {
+		rotatingFileHandler.size = info.Size()
+	}
+	return rotatingFileHandler
+}
+
+func (h *RotatingFileHandler) Handle(logRecord *spoor.LogRecord) {
+	h.Emit(logRecord)
+}
+
+func (h *RotatingFileHandler) Emit(logRecord *spoor.LogRecord) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	line := h.Format(logRecord) + "\n"
+	if h.shouldRollover(len(line)) {
+		h.doRollover()
+	}
+	fmt.Fprint(h.file, line)
+	h.size += int64(len(line))
+}
+
+func (h *RotatingFileHandler) shouldRollover(msgLen int) bool {
+	return h.maxBytes > 0 && h.size+int64(msgLen) > h.maxBytes
+}
+
+func (h *RotatingFileHandler) doRollover() error {
+	if h.file != nil {
+		h.file.Close()
+	}
+	if h.backupCount > 0 {
+		for i := h.backupCount - 1; i >= 1; i-- {
+			src := fmt.Sprintf("%s.%d", h.filename, i)
+			dst := fmt.Sprintf("%s.%d", h.filename, i+1)
+			if _, err := os.Stat(src); err == nil {
+				os.Remove(dst)
+				os.Rename(src, dst)
+			}
+		}
+		dst := h.filename + ".1"
+		os.Remove(dst)
+		os.Rename(h.filename, dst)
+	}
+	err := h.open()
+	h.size = 0
+	return err
+}
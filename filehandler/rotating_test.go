@@ -0,0 +1,52 @@
+package filehandler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/henkburgstra/spoor"
+)
+
+func TestRotatingFileHandlerSizeRollover(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	h := NewRotatingFileHandler(path, "a", 50, 2)
+	logger := spoor.NewLogger("rotating-test")
+	logger.AddHandler(h)
+
+	for i := 0; i < 20; i++ {
+		logger.Info("line number %d of text", i)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("base file missing: %v", err)
+	}
+	if _, err := os.Stat(path + ".1"); err != nil {
+		t.Fatalf("expected a backup file at %s.1: %v", path, err)
+	}
+}
+
+func TestRotatingFileHandlerPrunesBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	h := NewRotatingFileHandler(path, "a", 20, 2)
+	logger := spoor.NewLogger("rotating-prune-test")
+	logger.AddHandler(h)
+
+	for i := 0; i < 40; i++ {
+		logger.Info("line %d", i)
+	}
+
+	if _, err := os.Stat(path + ".1"); err != nil {
+		t.Errorf("expected %s.1 to exist: %v", path, err)
+	}
+	if _, err := os.Stat(path + ".2"); err != nil {
+		t.Errorf("expected %s.2 to exist: %v", path, err)
+	}
+	if _, err := os.Stat(path + ".3"); err == nil {
+		t.Errorf("expected %s.3 to have been pruned (BackupCount=2)", path)
+	}
+}
@@ -0,0 +1,134 @@
+package filehandler
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/henkburgstra/spoor"
+)
+
+// RolloverWhen selects the interval on which a TimedRotatingFileHandler
+// rolls its file over, mirroring the `when` values of Python's
+// TimedRotatingFileHandler.
+type RolloverWhen string
+
+const (
+	Hourly   RolloverWhen = "hourly"
+	Daily    RolloverWhen = "daily"
+	Midnight RolloverWhen = "midnight"
+	Weekday  RolloverWhen = "weekday"
+)
+
+// TimedRotatingFileHandler is a FileHandler that rolls the file over on a
+// time interval rather than on size, renaming the rolled-over file to
+// name.YYYY-MM-DD and keeping at most BackupCount backups.
+type TimedRotatingFileHandler struct {
+	FileHandler
+	when        RolloverWhen
+	weekday     time.Weekday
+	backupCount int
+	rolloverAt  time.Time
+	now         func() time.Time
+}
+
+func NewTimedRotatingFileHandler(filename string, mode string, when RolloverWhen, backupCount int) *TimedRotatingFileHandler {
+	timedRotatingFileHandler := new(TimedRotatingFileHandler)
+	timedRotatingFileHandler.FileHandler = *NewFileHandler(filename, mode)
+	timedRotatingFileHandler.when = when
+	timedRotatingFileHandler.backupCount = backupCount
+	timedRotatingFileHandler.now = time.Now
+	timedRotatingFileHandler.rolloverAt = timedRotatingFileHandler.nextRollover(timedRotatingFileHandler.now())
+	return timedRotatingFileHandler
+}
+
+// SetWeekday chooses the day of week rollover happens on when When is
+// Weekday. It is ignored for every other When value.
+func (h *TimedRotatingFileHandler) SetWeekday(weekday time.Weekday) {
+	h.weekday = weekday
+	h.rolloverAt = h.nextRollover(h.now())
+}
+
+// SetClock overrides the clock used to decide when to roll the file over,
+// for tests that need to exercise day-boundary rollover deterministically.
+func (h *TimedRotatingFileHandler) SetClock(now func() time.Time) {
+	h.now = now
+	h.rolloverAt = h.nextRollover(h.now())
+}
+
+func (h *TimedRotatingFileHandler) nextRollover(now time.Time) time.Time {
+	switch h.when {
+	case Hourly:
+		return now.Truncate(time.Hour).Add(time.Hour)
+	case Midnight:
+		year, month, day := now.Date()
+		midnight := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+		return midnight.AddDate(0, 0, 1)
+	case Weekday:
+		year, month, day := now.Date()
+		midnight := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+		daysAhead := (int(h.weekday) - int(midnight.Weekday()) + 7) % 7
+		if daysAhead == 0 {
+			daysAhead = 7
+		}
+		return midnight.AddDate(0, 0, daysAhead)
+	default: // Daily
+		return now.Add(24 * time.Hour)
+	}
+}
+
+func (h *TimedRotatingFileHandler) Handle(logRecord *spoor.LogRecord) {
+	h.Emit(logRecord)
+}
+
+func (h *TimedRotatingFileHandler) Emit(logRecord *spoor.LogRecord) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.now().Before(h.rolloverAt) {
+		h.doRollover()
+	}
+	fmt.Fprintln(h.file, h.Format(logRecord))
+}
+
+// intervalDuration returns the length of one rollover period for h.when, so
+// doRollover can derive the archive suffix from the period that just ended
+// rather than from the moment rollover happened to run.
+func (h *TimedRotatingFileHandler) intervalDuration() time.Duration {
+	switch h.when {
+	case Hourly:
+		return time.Hour
+	case Weekday:
+		return 7 * 24 * time.Hour
+	default: // Daily, Midnight
+		return 24 * time.Hour
+	}
+}
+
+func (h *TimedRotatingFileHandler) doRollover() error {
+	if h.file != nil {
+		h.file.Close()
+	}
+	suffix := h.rolloverAt.Add(-h.intervalDuration()).Format("2006-01-02")
+	dst := fmt.Sprintf("%s.%s", h.filename, suffix)
+	os.Remove(dst)
+	os.Rename(h.filename, dst)
+	h.pruneBackups()
+	h.rolloverAt = h.nextRollover(h.now())
+	return h.open()
+}
+
+func (h *TimedRotatingFileHandler) pruneBackups() {
+	if h.backupCount <= 0 {
+		return
+	}
+	backups, err := filepath.Glob(h.filename + ".*")
+	if err != nil || len(backups) <= h.backupCount {
+		return
+	}
+	sort.Strings(backups)
+	for _, old := range backups[:len(backups)-h.backupCount] {
+		os.Remove(old)
+	}
+}
@@ -0,0 +1,62 @@
+package filehandler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/henkburgstra/spoor"
+)
+
+func TestTimedRotatingFileHandlerMidnightRollover(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	now := time.Date(2026, 7, 28, 23, 59, 0, 0, time.UTC)
+	h := NewTimedRotatingFileHandler(path, "a", Midnight, 2)
+	h.SetClock(func() time.Time { return now })
+
+	logger := spoor.NewLogger("timed-rotating-test")
+	logger.AddHandler(h)
+
+	logger.Info("last message of the 28th")
+	now = now.Add(2 * time.Minute) // cross midnight into the 29th
+	logger.Info("first message of the 29th")
+
+	// The rolled-over file holds the 28th's record, so it must be dated
+	// 2026-07-28, not the 29th (the day rollover actually ran).
+	backup := path + ".2026-07-28"
+	if _, err := os.Stat(backup); err != nil {
+		t.Fatalf("expected backup dated for the day that was rolled out, %s: %v", backup, err)
+	}
+	if _, err := os.Stat(path + ".2026-07-29"); err == nil {
+		t.Fatalf("backup was dated for the day rollover ran instead of the day it archived")
+	}
+}
+
+func TestTimedRotatingFileHandlerPrunesBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	now := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	h := NewTimedRotatingFileHandler(path, "a", Daily, 2)
+	h.SetClock(func() time.Time { return now })
+
+	logger := spoor.NewLogger("timed-rotating-prune-test")
+	logger.AddHandler(h)
+
+	for day := 0; day < 4; day++ {
+		logger.Info("message for day %d", day)
+		now = now.Add(24 * time.Hour)
+	}
+	logger.Info("final message")
+
+	backups, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("glob: %v", err)
+	}
+	if len(backups) > 2 {
+		t.Errorf("expected at most 2 backups (BackupCount=2), got %d: %v", len(backups), backups)
+	}
+}
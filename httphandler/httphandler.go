@@ -0,0 +1,132 @@
+// Package httphandler POSTs batched log records as JSON to an HTTP
+// collector, flushing on a batch size or time interval.
+package httphandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/henkburgstra/spoor"
+)
+
+type batchedRecord struct {
+	Level string `json:"level"`
+	Msg   string `json:"msg"`
+}
+
+// HTTPHandler batches records and POSTs them as a JSON array to URL,
+// either once BatchSize records have accumulated or every FlushInterval,
+// whichever comes first. It satisfies spoor.ILogHandler and so can be
+// wrapped in a spoor.AsyncHandler to keep the HTTP round trip off the
+// caller's goroutine.
+type HTTPHandler struct {
+	spoor.LogHandler
+	url         string
+	username    string
+	password    string
+	bearerToken string
+	batchSize   int
+	client      *http.Client
+
+	mu      sync.Mutex
+	batch   []batchedRecord
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+func NewHTTPHandler(url string, batchSize int, flushInterval time.Duration) *HTTPHandler {
+	httpHandler := new(HTTPHandler)
+	httpHandler.LogHandler = *spoor.NewLogHandler()
+	httpHandler.url = url
+	httpHandler.batchSize = batchSize
+	httpHandler.client = &http.Client{Timeout: 10 * time.Second}
+	httpHandler.closeCh = make(chan struct{})
+	httpHandler.wg.Add(1)
+	go httpHandler.flushLoop(flushInterval)
+	return httpHandler
+}
+
+// SetBasicAuth configures HTTP basic auth for the batch POST requests.
+func (h *HTTPHandler) SetBasicAuth(username, password string) {
+	h.username = username
+	h.password = password
+}
+
+// SetBearerToken configures bearer token auth, taking precedence over basic
+// auth when both are set.
+func (h *HTTPHandler) SetBearerToken(token string) {
+	h.bearerToken = token
+}
+
+func (h *HTTPHandler) Handle(logRecord *spoor.LogRecord) {
+	h.Emit(logRecord)
+}
+
+func (h *HTTPHandler) Emit(logRecord *spoor.LogRecord) {
+	h.mu.Lock()
+	h.batch = append(h.batch, batchedRecord{Level: logRecord.GetLevel().String(), Msg: h.Format(logRecord)})
+	shouldFlush := len(h.batch) >= h.batchSize
+	h.mu.Unlock()
+	if shouldFlush {
+		h.flush()
+	}
+}
+
+func (h *HTTPHandler) flushLoop(flushInterval time.Duration) {
+	defer h.wg.Done()
+	if flushInterval <= 0 {
+		// No periodic flush; records only flush once BatchSize is reached.
+		<-h.closeCh
+		h.flush()
+		return
+	}
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			h.flush()
+		case <-h.closeCh:
+			h.flush()
+			return
+		}
+	}
+}
+
+func (h *HTTPHandler) flush() {
+	h.mu.Lock()
+	records := h.batch
+	h.batch = nil
+	h.mu.Unlock()
+	if len(records) == 0 {
+		return
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return
+	}
+	req, err := http.NewRequest(http.MethodPost, h.url, bytes.NewReader(data))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+h.bearerToken)
+	} else if h.username != "" {
+		req.SetBasicAuth(h.username, h.password)
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
+
+// Close flushes any pending records and stops the background flush loop.
+func (h *HTTPHandler) Close() {
+	close(h.closeCh)
+	h.wg.Wait()
+}
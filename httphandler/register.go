@@ -0,0 +1,49 @@
+package httphandler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/henkburgstra/spoor"
+	"github.com/henkburgstra/spoor/config"
+)
+
+func init() {
+	config.RegisterHandlerFactory("http", newHTTPHandlerFromConfig)
+}
+
+func newHTTPHandlerFromConfig(params map[string]interface{}) (spoor.ILogHandler, error) {
+	url, _ := params["url"].(string)
+	if url == "" {
+		return nil, fmt.Errorf("httphandler: config requires a url")
+	}
+	batchSize := 20
+	if n, ok := toInt(params["batchSize"]); ok {
+		batchSize = n
+	}
+	flushInterval := 5 * time.Second
+	if n, ok := toInt(params["flushIntervalSeconds"]); ok {
+		flushInterval = time.Duration(n) * time.Second
+	}
+	h := NewHTTPHandler(url, batchSize, flushInterval)
+	if token, ok := params["bearerToken"].(string); ok && token != "" {
+		h.SetBearerToken(token)
+	} else if username, ok := params["username"].(string); ok {
+		password, _ := params["password"].(string)
+		h.SetBasicAuth(username, password)
+	}
+	return h, nil
+}
+
+func toInt(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	default:
+		return 0, false
+	}
+}
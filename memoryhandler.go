@@ -0,0 +1,55 @@
+package spoor
+
+import "sync"
+
+// MemoryHandler buffers records in memory and flushes them to a target
+// handler once capacity records have accumulated or a record at or above
+// triggerLevel arrives, mirroring Python's logging.handlers.MemoryHandler.
+type MemoryHandler struct {
+	LogHandler
+	target       ILogHandler
+	capacity     int
+	triggerLevel LogLevel
+	buffer       []*LogRecord
+	mu           sync.Mutex
+}
+
+func NewMemoryHandler(target ILogHandler, capacity int, triggerLevel LogLevel) *MemoryHandler {
+	memoryHandler := new(MemoryHandler)
+	memoryHandler.LogHandler = *NewLogHandler()
+	memoryHandler.target = target
+	memoryHandler.capacity = capacity
+	memoryHandler.triggerLevel = triggerLevel
+	return memoryHandler
+}
+
+func (h *MemoryHandler) Handle(logRecord *LogRecord) {
+	h.Emit(logRecord)
+}
+
+func (h *MemoryHandler) Emit(logRecord *LogRecord) {
+	h.mu.Lock()
+	h.buffer = append(h.buffer, logRecord)
+	shouldFlush := len(h.buffer) >= h.capacity || logRecord.level >= h.triggerLevel
+	h.mu.Unlock()
+	if shouldFlush {
+		h.Flush()
+	}
+}
+
+// Flush sends every buffered record to the target handler and empties the
+// buffer.
+func (h *MemoryHandler) Flush() {
+	h.mu.Lock()
+	records := h.buffer
+	h.buffer = nil
+	h.mu.Unlock()
+	for _, logRecord := range records {
+		h.target.Handle(logRecord)
+	}
+}
+
+// Close flushes any remaining buffered records.
+func (h *MemoryHandler) Close() {
+	h.Flush()
+}
@@ -0,0 +1,21 @@
+package servicehandler
+
+import (
+	"fmt"
+
+	"github.com/henkburgstra/spoor"
+	"github.com/henkburgstra/spoor/config"
+)
+
+func init() {
+	config.RegisterHandlerFactory("service", newServiceHandlerFromConfig)
+}
+
+// newServiceHandlerFromConfig always fails: a ServiceHandler wraps a live
+// service.Service obtained from the OS service manager, which a flat config
+// document has no way to express. It is registered anyway so a config
+// referencing "service" fails with a clear message instead of "unknown
+// class".
+func newServiceHandlerFromConfig(params map[string]interface{}) (spoor.ILogHandler, error) {
+	return nil, fmt.Errorf("servicehandler: cannot be built from config; construct it with NewServiceHandler and AddHandler it directly")
+}
@@ -0,0 +1,166 @@
+// Package sockethandler sends log records over a TCP or UDP socket, with
+// a choice of wire formats and automatic reconnect on write failure.
+package sockethandler
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/henkburgstra/spoor"
+)
+
+// WireFormat selects how a SocketHandler frames each record on the wire.
+type WireFormat int
+
+const (
+	// LineText writes the formatted record followed by a newline.
+	LineText WireFormat = iota
+	// LengthPrefixedGob writes a 4-byte big-endian length prefix followed
+	// by the gob encoding of the formatted record.
+	LengthPrefixedGob
+	// JSONLines writes one JSON object per line.
+	JSONLines
+)
+
+// SocketHandler emits records to a TCP or UDP socket. A dropped connection
+// is reconnected with exponential backoff on a background goroutine;
+// records that arrive while a reconnect is in flight are dropped rather
+// than blocking the caller.
+type SocketHandler struct {
+	spoor.LogHandler
+	network      string
+	addr         string
+	format       WireFormat
+	maxRetries   int
+	maxBackoff   time.Duration
+	conn         net.Conn
+	mu           sync.Mutex
+	reconnecting bool
+}
+
+func NewSocketHandler(network, addr string, format WireFormat) *SocketHandler {
+	socketHandler := new(SocketHandler)
+	socketHandler.LogHandler = *spoor.NewLogHandler()
+	socketHandler.network = network
+	socketHandler.addr = addr
+	socketHandler.format = format
+	socketHandler.maxRetries = 5
+	socketHandler.maxBackoff = 30 * time.Second
+
+	// Dial eagerly so a healthy sink doesn't drop the first records logged
+	// right after startup while waiting for Emit to notice conn == nil and
+	// kick off a reconnect. Tolerate failure here the same way Emit does.
+	socketHandler.mu.Lock()
+	if conn, err := net.DialTimeout(network, addr, 5*time.Second); err == nil {
+		socketHandler.conn = conn
+	} else {
+		socketHandler.startReconnect()
+	}
+	socketHandler.mu.Unlock()
+	return socketHandler
+}
+
+func (h *SocketHandler) Handle(logRecord *spoor.LogRecord) {
+	h.Emit(logRecord)
+}
+
+func (h *SocketHandler) Emit(logRecord *spoor.LogRecord) {
+	h.mu.Lock()
+	conn := h.conn
+	if conn == nil {
+		h.startReconnect()
+		h.mu.Unlock()
+		return
+	}
+	h.mu.Unlock()
+	if err := h.write(conn, logRecord); err != nil {
+		h.mu.Lock()
+		if h.conn == conn {
+			conn.Close()
+			h.conn = nil
+			h.startReconnect()
+		}
+		h.mu.Unlock()
+	}
+}
+
+// startReconnect kicks off a background goroutine that redials the
+// configured address with exponential backoff, up to maxRetries times.
+// It must be called with h.mu held. Reconnecting off the emit path keeps
+// a down sink from blocking every caller of Logger.Log for the duration
+// of the backoff; records that arrive before the reconnect succeeds are
+// simply dropped.
+func (h *SocketHandler) startReconnect() {
+	if h.reconnecting {
+		return
+	}
+	h.reconnecting = true
+	go func() {
+		backoff := 100 * time.Millisecond
+		for attempt := 0; attempt < h.maxRetries; attempt++ {
+			conn, err := net.DialTimeout(h.network, h.addr, 5*time.Second)
+			if err == nil {
+				h.mu.Lock()
+				h.conn = conn
+				h.reconnecting = false
+				h.mu.Unlock()
+				return
+			}
+			time.Sleep(backoff)
+			backoff *= 2
+			if backoff > h.maxBackoff {
+				backoff = h.maxBackoff
+			}
+		}
+		h.mu.Lock()
+		h.reconnecting = false
+		h.mu.Unlock()
+	}()
+}
+
+func (h *SocketHandler) write(conn net.Conn, logRecord *spoor.LogRecord) error {
+	msg := h.Format(logRecord)
+	switch h.format {
+	case JSONLines:
+		data, err := json.Marshal(struct {
+			Level string `json:"level"`
+			Msg   string `json:"msg"`
+		}{logRecord.GetLevel().String(), msg})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(conn, "%s\n", data)
+		return err
+	case LengthPrefixedGob:
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(msg); err != nil {
+			return err
+		}
+		if err := binary.Write(conn, binary.BigEndian, uint32(buf.Len())); err != nil {
+			return err
+		}
+		_, err := conn.Write(buf.Bytes())
+		return err
+	default: // LineText
+		_, err := fmt.Fprintf(conn, "%s\n", msg)
+		return err
+	}
+}
+
+// Close releases the underlying socket, if connected.
+func (h *SocketHandler) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.conn == nil {
+		return nil
+	}
+	err := h.conn.Close()
+	h.conn = nil
+	return err
+}
@@ -2,9 +2,12 @@
 package spoor
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -41,17 +44,26 @@ func (loglevel LogLevel) String() string {
 }
 
 type LogRecord struct {
-	level LogLevel
-	name  string
-	msg   string
-	args  []interface{}
+	level  LogLevel
+	name   string
+	msg    string
+	args   []interface{}
+	fields map[string]interface{}
+	caller string
 }
 
 func NewLogRecord(level LogLevel, name string, msg string, args ...interface{}) *LogRecord {
+	return NewLogRecordWithFields(level, name, msg, nil, args...)
+}
+
+// NewLogRecordWithFields is NewLogRecord plus the structured key/value pairs
+// returned later by GetFields.
+func NewLogRecordWithFields(level LogLevel, name string, msg string, fields map[string]interface{}, args ...interface{}) *LogRecord {
 	logRecord := new(LogRecord)
 	logRecord.level = level
 	logRecord.name = name
 	logRecord.msg = msg
+	logRecord.fields = fields
 	logRecord.args = args
 	return logRecord
 }
@@ -60,13 +72,26 @@ func (r *LogRecord) GetLevel() LogLevel {
 	return r.level
 }
 
-type Formatter struct {
+// GetFields returns the key/value pairs attached to the record, merged from
+// the logger's persistent fields (see Logger.With) and any per-call kv pairs.
+func (r *LogRecord) GetFields() map[string]interface{} {
+	return r.fields
+}
+
+// Formatter renders a LogRecord to its final textual representation.
+type Formatter interface {
+	Format(logRecord *LogRecord) string
+}
+
+// TextFormatter is the original "{levelname}: {asctime} - {message}" style
+// formatter, extended to append any record fields as "key=value" pairs.
+type TextFormatter struct {
 	fmt     string
 	dateFmt string
 }
 
-func NewFormatter(params ...string) *Formatter {
-	formatter := new(Formatter)
+func NewFormatter(params ...string) *TextFormatter {
+	formatter := new(TextFormatter)
 	if len(params) > 0 {
 		formatter.fmt = params[0]
 	}
@@ -76,7 +101,7 @@ func NewFormatter(params ...string) *Formatter {
 	return formatter
 }
 
-func (f *Formatter) Format(logRecord *LogRecord) string {
+func (f *TextFormatter) Format(logRecord *LogRecord) string {
 	now := time.Now()
 	msg := ""
 	if len(logRecord.args) > 0 {
@@ -87,23 +112,89 @@ func (f *Formatter) Format(logRecord *LogRecord) string {
 	format := strings.Replace(f.fmt, "{levelname}", logRecord.level.String(), 1)
 	format = strings.Replace(format, "{message}", msg, 1)
 	format = strings.Replace(format, "{asctime}", now.Format(f.dateFmt), 1)
+	if fields := formatFields(logRecord.fields); fields != "" {
+		format = format + " " + fields
+	}
+	if logRecord.caller != "" {
+		format = format + " caller=" + logRecord.caller
+	}
 	return format
 }
 
+// formatFields renders fields as space separated "key=value" pairs, sorted
+// by key so the output is stable across runs.
+func formatFields(fields map[string]interface{}) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%v", k, fields[k]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// JSONFormatter emits one JSON object per record with "time", "level",
+// "logger" and "msg" keys, merged with the record's fields.
+type JSONFormatter struct {
+	dateFmt string
+}
+
+func NewJSONFormatter(params ...string) *JSONFormatter {
+	formatter := new(JSONFormatter)
+	formatter.dateFmt = time.RFC3339
+	if len(params) > 0 {
+		formatter.dateFmt = params[0]
+	}
+	return formatter
+}
+
+func (f *JSONFormatter) Format(logRecord *LogRecord) string {
+	now := time.Now()
+	msg := logRecord.msg
+	if len(logRecord.args) > 0 {
+		msg = fmt.Sprintf(logRecord.msg, logRecord.args...)
+	}
+	entry := make(map[string]interface{}, len(logRecord.fields)+5)
+	for k, v := range logRecord.fields {
+		entry[k] = v
+	}
+	entry["time"] = now.Format(f.dateFmt)
+	entry["level"] = logRecord.level.String()
+	entry["logger"] = logRecord.name
+	entry["msg"] = msg
+	if logRecord.caller != "" {
+		entry["caller"] = logRecord.caller
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Sprintf(`{"level":"ERROR","msg":"spoor: JSONFormatter: %s"}`, err)
+	}
+	return string(data)
+}
+
 type ILogHandler interface {
 	GetLevel() LogLevel
 	SetLevel(LogLevel)
-	GetFormatter() *Formatter
-	SetFormatter(*Formatter)
+	GetFormatter() Formatter
+	SetFormatter(Formatter)
+	IncludeCaller() bool
+	SetIncludeCaller(bool)
 	Handle(*LogRecord)
 	Emit(*LogRecord)
 	Format(*LogRecord) string
 }
 
 type LogHandler struct {
-	level     LogLevel
-	formatter *Formatter
-	logger    io.Writer
+	level         LogLevel
+	formatter     Formatter
+	logger        io.Writer
+	includeCaller bool
 }
 
 func NewLogHandler() *LogHandler {
@@ -121,14 +212,33 @@ func (h *LogHandler) SetLevel(level LogLevel) {
 	h.level = level
 }
 
-func (h *LogHandler) GetFormatter() *Formatter {
+func (h *LogHandler) GetFormatter() Formatter {
 	return h.formatter
 }
 
-func (h *LogHandler) SetFormatter(formatter *Formatter) {
+func (h *LogHandler) SetFormatter(formatter Formatter) {
 	h.formatter = formatter
 }
 
+// SetWriter swaps the underlying writer, leaving the formatter, level and
+// includeCaller settings untouched. Handlers that redirect output (e.g. a
+// FileHandler reopening its file) should use this instead of rebuilding the
+// handler, so user-configured settings survive the swap.
+func (h *LogHandler) SetWriter(w io.Writer) {
+	h.logger = w
+}
+
+// IncludeCaller reports whether this handler wants the caller's file/line
+// captured on every record it handles.
+func (h *LogHandler) IncludeCaller() bool {
+	return h.includeCaller
+}
+
+// SetIncludeCaller enables or disables caller capture for this handler.
+func (h *LogHandler) SetIncludeCaller(includeCaller bool) {
+	h.includeCaller = includeCaller
+}
+
 func (h *LogHandler) Handle(logRecord *LogRecord) {
 	h.Emit(logRecord)
 }
@@ -158,15 +268,20 @@ func NewStreamHandler(stream ...io.Writer) *StreamHandler {
 }
 
 type Logger struct {
-	level    LogLevel
-	name     string
-	handlers []ILogHandler
+	level     LogLevel
+	hasLevel  bool
+	name      string
+	parent    *Logger
+	propagate bool
+	handlers  []ILogHandler
+	fields    map[string]interface{}
 }
 
 func NewLogger(name string) *Logger {
 	logger := new(Logger)
 	logger.name = name
 	logger.handlers = make([]ILogHandler, 0, 2)
+	logger.propagate = true
 	return logger
 }
 
@@ -174,44 +289,185 @@ func (l *Logger) GetName() string {
 	return l.name
 }
 
+// SetLevel sets l's own level, overriding whatever it would otherwise
+// inherit from its nearest ancestor. See EffectiveLevel.
 func (l *Logger) SetLevel(level LogLevel) {
 	l.level = level
+	l.hasLevel = true
 }
 
-func (l *Logger) Log(level LogLevel, msg string, args ...interface{}) {
-	logRecord := NewLogRecord(level, l.name, msg, args...)
-	for _, handler := range l.handlers {
-		if level >= handler.GetLevel() {
-			handler.Handle(logRecord)
+// EffectiveLevel returns l's level, or, if none was set via SetLevel, the
+// level of the nearest ancestor that has one. A logger with no level set
+// anywhere in its chain (including the root) defaults to INFO.
+func (l *Logger) EffectiveLevel() LogLevel {
+	for logger := l; logger != nil; logger = logger.parent {
+		if logger.hasLevel {
+			return logger.level
+		}
+	}
+	return INFO
+}
+
+// SetPropagate controls whether records logged on l are also passed to its
+// ancestors' handlers. It defaults to true.
+func (l *Logger) SetPropagate(propagate bool) {
+	l.propagate = propagate
+}
+
+// With returns a child logger that carries kv (alternating key, value)
+// as persistent fields on every record it logs, in addition to any fields
+// already carried by l.
+func (l *Logger) With(kv ...interface{}) *Logger {
+	child := new(Logger)
+	child.name = l.name
+	child.level = l.level
+	child.hasLevel = l.hasLevel
+	child.parent = l.parent
+	child.propagate = l.propagate
+	child.handlers = l.handlers
+	child.fields = mergeFields(l.fields, parseFields(kv))
+	return child
+}
+
+// parseFields turns alternating key, value pairs into a field map. Keys that
+// are not strings are dropped.
+func parseFields(kv []interface{}) map[string]interface{} {
+	if len(kv) == 0 {
+		return nil
+	}
+	fields := make(map[string]interface{}, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			continue
+		}
+		fields[key] = kv[i+1]
+	}
+	return fields
+}
+
+func mergeFields(base, extra map[string]interface{}) map[string]interface{} {
+	if len(base) == 0 && len(extra) == 0 {
+		return nil
+	}
+	merged := make(map[string]interface{}, len(base)+len(extra))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range extra {
+		merged[k] = v
+	}
+	return merged
+}
+
+// wantsCaller reports whether l or any ancestor it propagates to has a
+// handler with IncludeCaller enabled.
+func (l *Logger) wantsCaller() bool {
+	for logger := l; logger != nil; logger = logger.parent {
+		for _, handler := range logger.handlers {
+			if handler.IncludeCaller() {
+				return true
+			}
+		}
+		if !logger.propagate {
+			break
 		}
 	}
-	if level == FATAL {
+	return false
+}
+
+// dispatch walks from l up through its ancestors, handing logRecord to every
+// handler along the way whose level admits it, stopping at the first logger
+// (including l itself) whose propagate flag is false.
+func (l *Logger) dispatch(logRecord *LogRecord) {
+	for logger := l; logger != nil; logger = logger.parent {
+		for _, handler := range logger.handlers {
+			if logRecord.level >= handler.GetLevel() {
+				handler.Handle(logRecord)
+			}
+		}
+		if !logger.propagate {
+			break
+		}
+	}
+	if logRecord.level == FATAL {
 		os.Exit(1)
 	}
 }
 
+// log builds a LogRecord from fields and args (exactly one of which is
+// normally set) and dispatches it to every handler interested in level.
+// It is called directly from Log, LogKV and every level-specific method so
+// that runtime.Caller(2) always resolves to the application's call site.
+func (l *Logger) log(level LogLevel, msg string, fields map[string]interface{}, args []interface{}) {
+	if level < l.EffectiveLevel() {
+		return
+	}
+	logRecord := NewLogRecordWithFields(level, l.name, msg, mergeFields(l.fields, fields), args...)
+	if l.wantsCaller() {
+		if _, file, line, ok := runtime.Caller(2); ok {
+			logRecord.caller = fmt.Sprintf("%s:%d", file, line)
+		}
+	}
+	l.dispatch(logRecord)
+}
+
+func (l *Logger) Log(level LogLevel, msg string, args ...interface{}) {
+	l.log(level, msg, nil, args)
+}
+
+// LogKV logs msg at level with kv (alternating key, value) attached to the
+// record as fields, in the style of hclog/logrus.
+func (l *Logger) LogKV(level LogLevel, msg string, kv ...interface{}) {
+	l.log(level, msg, parseFields(kv), nil)
+}
+
 func (l *Logger) Debug(msg string, args ...interface{}) {
-	l.Log(DEBUG, msg, args...)
+	l.log(DEBUG, msg, nil, args)
 }
 
 func (l *Logger) Info(msg string, args ...interface{}) {
-	l.Log(INFO, msg, args...)
+	l.log(INFO, msg, nil, args)
 }
 
 func (l *Logger) Warn(msg string, args ...interface{}) {
-	l.Log(WARNING, msg, args...)
+	l.log(WARNING, msg, nil, args)
 }
 
 func (l *Logger) Error(msg string, args ...interface{}) {
-	l.Log(ERROR, msg, args...)
+	l.log(ERROR, msg, nil, args)
 }
 
 func (l *Logger) Critical(msg string, args ...interface{}) {
-	l.Log(CRITICAL, msg, args...)
+	l.log(CRITICAL, msg, nil, args)
 }
 
 func (l *Logger) Fatal(msg string, args ...interface{}) {
-	l.Log(FATAL, msg, args...)
+	l.log(FATAL, msg, nil, args)
+}
+
+func (l *Logger) DebugKV(msg string, kv ...interface{}) {
+	l.log(DEBUG, msg, parseFields(kv), nil)
+}
+
+func (l *Logger) InfoKV(msg string, kv ...interface{}) {
+	l.log(INFO, msg, parseFields(kv), nil)
+}
+
+func (l *Logger) WarnKV(msg string, kv ...interface{}) {
+	l.log(WARNING, msg, parseFields(kv), nil)
+}
+
+func (l *Logger) ErrorKV(msg string, kv ...interface{}) {
+	l.log(ERROR, msg, parseFields(kv), nil)
+}
+
+func (l *Logger) CriticalKV(msg string, kv ...interface{}) {
+	l.log(CRITICAL, msg, parseFields(kv), nil)
+}
+
+func (l *Logger) FatalKV(msg string, kv ...interface{}) {
+	l.log(FATAL, msg, parseFields(kv), nil)
 }
 
 func (l *Logger) AddHandler(handler ILogHandler) {
@@ -234,6 +490,10 @@ var config = struct {
 	// Note that this argument is incompatible with ‘filename’ - if both are present, ‘stream’ is ignored.
 }{level: INFO, format: "{levelname}: {asctime} - {message}", datefmt: "2006-01-02 15:04:05"}
 
+// BasicConfig applies conf to the package-wide defaults used when building
+// new handlers, then attaches a StreamHandler to the root logger so every
+// named logger can reach it by default, unless the root logger already has
+// handlers (e.g. from an earlier BasicConfig call).
 func BasicConfig(conf map[string]interface{}) {
 	for k, value := range conf {
 		key := strings.ToLower(k)
@@ -250,19 +510,58 @@ func BasicConfig(conf map[string]interface{}) {
 			config.stream = value.(io.Writer)
 		}
 	}
+	root := RootLogger()
+	if len(root.handlers) > 0 {
+		return
+	}
+	root.SetLevel(config.level)
+	if config.stream != nil {
+		root.AddHandler(NewStreamHandler(config.stream))
+	} else {
+		root.AddHandler(NewStreamHandler())
+	}
 }
 
+// RootLogger returns the root of the logger hierarchy. Every other logger,
+// however deeply named, eventually propagates up to it.
+func RootLogger() *Logger {
+	return GetLogger()
+}
+
+// GetLogger returns the logger named by the dotted loggername (e.g.
+// "app.db.pool"), creating it - and any ancestor implied by its name that
+// doesn't exist yet - on first use. With no argument it returns the root
+// logger.
 func GetLogger(loggername ...string) *Logger {
-	loggers.Lock()
-	defer loggers.Unlock()
 	name := "root"
-	if len(loggername) == 1 {
+	if len(loggername) == 1 && loggername[0] != "" {
 		name = loggername[0]
 	}
+	loggers.Lock()
+	defer loggers.Unlock()
+	return getOrCreateLogger(name)
+}
+
+// getOrCreateLogger returns the logger named name, creating it and linking
+// it to its parent (creating that too, if needed) on first use. Callers
+// must hold loggers' lock.
+func getOrCreateLogger(name string) *Logger {
 	if logger, ok := loggers.items[name]; ok {
 		return logger
 	}
 	logger := NewLogger(name)
 	loggers.items[name] = logger
+	if name != "root" {
+		logger.parent = getOrCreateLogger(parentName(name))
+	}
 	return logger
 }
+
+// parentName returns the dotted parent of name, or "root" if name has no
+// "." separator, i.e. it is a direct child of the root logger.
+func parentName(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[:i]
+	}
+	return "root"
+}
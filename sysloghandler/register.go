@@ -0,0 +1,38 @@
+package sysloghandler
+
+import (
+	"github.com/henkburgstra/spoor"
+	"github.com/henkburgstra/spoor/config"
+)
+
+func init() {
+	config.RegisterHandlerFactory("syslog", newSyslogHandlerFromConfig)
+}
+
+func newSyslogHandlerFromConfig(params map[string]interface{}) (spoor.ILogHandler, error) {
+	facility := Facility(0)
+	if n, ok := toInt(params["facility"]); ok {
+		facility = Facility(n)
+	}
+	appName, _ := params["appName"].(string)
+	network, _ := params["network"].(string)
+	if network == "" {
+		return NewLocalSyslogHandler(facility, appName)
+	}
+	addr, _ := params["address"].(string)
+	hostname, _ := params["hostname"].(string)
+	return NewNetworkSyslogHandler(network, addr, facility, hostname, appName)
+}
+
+func toInt(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	default:
+		return 0, false
+	}
+}
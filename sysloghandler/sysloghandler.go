@@ -0,0 +1,157 @@
+// Package sysloghandler sends log records to a local Unix syslog daemon or
+// to a remote syslog collector using RFC 5424 framing over UDP or TCP.
+package sysloghandler
+
+import (
+	"fmt"
+	"log/syslog"
+	"net"
+	"os"
+	"time"
+
+	"github.com/henkburgstra/spoor"
+)
+
+// Facility is a syslog facility code, as defined in RFC 5424.
+type Facility int
+
+const (
+	FacKern Facility = iota
+	FacUser
+	FacMail
+	FacDaemon
+	FacAuth
+	FacSyslog
+	FacLPR
+	FacNews
+	FacUUCP
+	FacCron
+	FacAuthPriv
+	FacFTP
+	FacNTP
+	FacSecurity
+	FacConsole
+	FacSolarisCron
+	FacLocal0
+	FacLocal1
+	FacLocal2
+	FacLocal3
+	FacLocal4
+	FacLocal5
+	FacLocal6
+	FacLocal7
+)
+
+// severity maps a spoor.LogLevel onto the RFC 5424 severity scale
+// (0 Emergency .. 7 Debug).
+func severity(level spoor.LogLevel) int {
+	switch level {
+	case spoor.DEBUG:
+		return 7
+	case spoor.INFO:
+		return 6
+	case spoor.WARNING:
+		return 4
+	case spoor.ERROR:
+		return 3
+	case spoor.CRITICAL:
+		return 2
+	case spoor.FATAL:
+		return 0
+	}
+	return 6
+}
+
+// SyslogHandler emits records either to the local syslog daemon or, once
+// dialed with NewNetworkSyslogHandler, to a remote collector as RFC 5424
+// messages.
+type SyslogHandler struct {
+	spoor.LogHandler
+	facility Facility
+	hostname string
+	appName  string
+	local    *syslog.Writer
+	conn     net.Conn
+}
+
+// NewLocalSyslogHandler connects to the local syslog daemon (e.g. /dev/log)
+// and tags every message with appName.
+func NewLocalSyslogHandler(facility Facility, appName string) (*SyslogHandler, error) {
+	writer, err := syslog.New(syslog.Priority(int(facility)<<3)|syslog.LOG_INFO, appName)
+	if err != nil {
+		return nil, err
+	}
+	syslogHandler := new(SyslogHandler)
+	syslogHandler.LogHandler = *spoor.NewLogHandler()
+	syslogHandler.facility = facility
+	syslogHandler.appName = appName
+	syslogHandler.local = writer
+	return syslogHandler, nil
+}
+
+// NewNetworkSyslogHandler dials network ("udp" or "tcp") addr and frames
+// every message as RFC 5424, using hostname and appName as the HOSTNAME and
+// APP-NAME fields.
+func NewNetworkSyslogHandler(network, addr string, facility Facility, hostname, appName string) (*SyslogHandler, error) {
+	conn, err := net.Dial(network, addr)
+	if err != nil {
+		return nil, err
+	}
+	syslogHandler := new(SyslogHandler)
+	syslogHandler.LogHandler = *spoor.NewLogHandler()
+	syslogHandler.facility = facility
+	syslogHandler.hostname = hostname
+	syslogHandler.appName = appName
+	syslogHandler.conn = conn
+	return syslogHandler, nil
+}
+
+func (h *SyslogHandler) Handle(logRecord *spoor.LogRecord) {
+	h.Emit(logRecord)
+}
+
+func (h *SyslogHandler) Emit(logRecord *spoor.LogRecord) {
+	msg := h.Format(logRecord)
+	if h.local != nil {
+		h.writeLocal(logRecord.GetLevel(), msg)
+		return
+	}
+	if h.conn != nil {
+		fmt.Fprint(h.conn, h.formatRFC5424(logRecord.GetLevel(), msg))
+	}
+}
+
+func (h *SyslogHandler) writeLocal(level spoor.LogLevel, msg string) {
+	switch {
+	case level >= spoor.CRITICAL:
+		h.local.Crit(msg)
+	case level >= spoor.ERROR:
+		h.local.Err(msg)
+	case level >= spoor.WARNING:
+		h.local.Warning(msg)
+	case level >= spoor.INFO:
+		h.local.Info(msg)
+	default:
+		h.local.Debug(msg)
+	}
+}
+
+func (h *SyslogHandler) formatRFC5424(level spoor.LogLevel, msg string) string {
+	pri := int(h.facility)*8 + severity(level)
+	hostname := h.hostname
+	if hostname == "" {
+		hostname, _ = os.Hostname()
+	}
+	return fmt.Sprintf("<%d>1 %s %s %s - - - %s\n", pri, time.Now().Format(time.RFC3339), hostname, h.appName, msg)
+}
+
+// Close releases the underlying syslog connection.
+func (h *SyslogHandler) Close() error {
+	if h.local != nil {
+		return h.local.Close()
+	}
+	if h.conn != nil {
+		return h.conn.Close()
+	}
+	return nil
+}